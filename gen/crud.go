@@ -0,0 +1,48 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+// writeCRUD emits Find/Insert/Delete query string constants for t, built
+// around its primary key column. Tables without a declared primary key are
+// skipped, since there is nothing to key the Find/Delete queries on.
+func writeCRUD(buf *bytes.Buffer, name string, t pqtbl.Table) {
+	pk := ""
+	all := make([]string, 0, len(t.Columns))
+	insertable := make([]string, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		all = append(all, c.Name)
+		if c.PrimaryKey {
+			pk = c.Name
+			continue
+		}
+		insertable = append(insertable, c.Name)
+	}
+	if pk == "" {
+		return
+	}
+
+	table := qualifiedTableName(t)
+
+	fmt.Fprintf(buf, "const %sFindQuery = `SELECT %s FROM %s WHERE %s = $1`\n\n", name, strings.Join(all, ", "), table, pk)
+
+	placeholders := make([]string, len(insertable))
+	for i := range insertable {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	fmt.Fprintf(buf, "const %sInsertQuery = `INSERT INTO %s (%s) VALUES (%s) RETURNING %s`\n\n", name, table, strings.Join(insertable, ", "), strings.Join(placeholders, ", "), pk)
+
+	fmt.Fprintf(buf, "const %sDeleteQuery = `DELETE FROM %s WHERE %s = $1`\n\n", name, table, pk)
+}
+
+func qualifiedTableName(t pqtbl.Table) string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
@@ -0,0 +1,114 @@
+// Package gen turns pqtbl.Table definitions into Go source: one struct per
+// table with json/db tags, a pqtbl.Columns variable for each, and optionally
+// a set of CRUD query string constants.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+// Option configures Generate.
+type Option func(*options)
+
+type options struct {
+	mapper TypeMapper
+	crud   bool
+}
+
+// WithTypeMapper selects the TypeMapper used to resolve each column's Go
+// type. Defaults to NiltMapper.
+func WithTypeMapper(m TypeMapper) Option {
+	return func(o *options) {
+		o.mapper = m
+	}
+}
+
+// WithCRUD additionally emits Find/Insert/Delete query string constants for
+// each table.
+func WithCRUD() Option {
+	return func(o *options) {
+		o.crud = true
+	}
+}
+
+// Generate renders gofmt-ed Go source declaring, for every table in tables,
+// a struct, a `<Table>Columns` pqtbl.Columns value and - when WithCRUD is
+// passed - Find/Insert/Delete query string constants.
+func Generate(pkg string, tables []pqtbl.Table, opts ...Option) ([]byte, error) {
+	o := &options{mapper: NiltMapper{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	imports := map[string]struct{}{"github.com/piotrkowalczuk/pqtbl": {}}
+
+	var body bytes.Buffer
+	for _, t := range tables {
+		if err := writeTable(&body, t, o, imports); err != nil {
+			return nil, err
+		}
+	}
+
+	importNames := make([]string, 0, len(imports))
+	for imp := range imports {
+		importNames = append(importNames, imp)
+	}
+	sort.Strings(importNames)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", pkg)
+	for _, imp := range importNames {
+		fmt.Fprintf(&buf, "\t%q\n", imp)
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+func writeTable(buf *bytes.Buffer, t pqtbl.Table, o *options, imports map[string]struct{}) error {
+	name := exportedName(t.Name)
+
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, c := range t.Columns {
+		typ, colImports, ok := o.mapper.GoType(c)
+		if !ok {
+			return fmt.Errorf("pqtbl/gen: no go type mapping for column %q of table %q", c.Name, t.Name)
+		}
+		for _, imp := range colImports {
+			imports[imp] = struct{}{}
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:%q db:%q`\n", exportedName(c.Name), typ, c.Name, c.Name)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "var %sColumns = pqtbl.Columns{\n", name)
+	for _, c := range t.Columns {
+		fmt.Fprintf(buf, "\t%q,\n", c.Name)
+	}
+	buf.WriteString("}\n\n")
+
+	if o.crud {
+		writeCRUD(buf, name, t)
+	}
+
+	return nil
+}
+
+func exportedName(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+
+	return strings.Join(parts, "")
+}
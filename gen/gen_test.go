@@ -0,0 +1,61 @@
+package gen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqtbl"
+	"github.com/piotrkowalczuk/pqtbl/gen"
+)
+
+func TestGenerate(t *testing.T) {
+	tables := []pqtbl.Table{
+		{
+			Name: "user",
+			Columns: []pqtbl.Column{
+				{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+				{Name: "username", Type: pqtbl.DataTypeText, NotNull: true},
+				{Name: "bio", Type: pqtbl.DataTypeText},
+			},
+		},
+	}
+
+	src, err := gen.Generate("model", tables, gen.WithTypeMapper(gen.StdMapper{}), gen.WithCRUD())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	out := string(src)
+	for _, want := range []string{
+		"package model",
+		"type User struct",
+		"Username",
+		"string",
+		"Bio",
+		"sql.NullString",
+		"var UserColumns = pqtbl.Columns{",
+		"const UserFindQuery",
+		"const UserInsertQuery",
+		"const UserDeleteQuery",
+		`"database/sql"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected generated source to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerate_missingTypeMapping(t *testing.T) {
+	tables := []pqtbl.Table{
+		{
+			Name: "widget",
+			Columns: []pqtbl.Column{
+				{Name: "payload", Type: "JSONB"},
+			},
+		},
+	}
+
+	if _, err := gen.Generate("model", tables); err == nil {
+		t.Fatal("expected an error for an unmapped column type")
+	}
+}
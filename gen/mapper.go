@@ -0,0 +1,140 @@
+package gen
+
+import (
+	"strings"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+// TypeMapper maps a pqtbl.Column onto the Go type that should represent it,
+// the imports that type requires, and whether a mapping exists at all.
+type TypeMapper interface {
+	GoType(c pqtbl.Column) (typ string, imports []string, ok bool)
+}
+
+// NiltMapper maps nullable columns onto github.com/piotrkowalczuk/nilt types,
+// delegating to the pqtbl.Column.GoType method that has shipped with this
+// package from the start. It is the default mapper, kept for compatibility
+// with generators written against that method directly.
+type NiltMapper struct{}
+
+// GoType implements TypeMapper.
+func (NiltMapper) GoType(c pqtbl.Column) (string, []string, bool) {
+	typ, ok := c.GoType()
+	if !ok {
+		return "", nil, false
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "nilt."):
+		return typ, []string{"github.com/piotrkowalczuk/nilt"}, true
+	case typ == "time.Time", typ == "*time.Time":
+		return typ, []string{"time"}, true
+	default:
+		return typ, nil, true
+	}
+}
+
+// StdMapper maps nullable columns onto database/sql.Null* types, for callers
+// who don't want a third-party nullable type dependency.
+type StdMapper struct{}
+
+// GoType implements TypeMapper.
+func (StdMapper) GoType(c pqtbl.Column) (string, []string, bool) {
+	optional := c.NotNull || c.PrimaryKey
+
+	switch c.Type {
+	case pqtbl.DataTypeText:
+		if optional {
+			return "string", nil, true
+		}
+		return "sql.NullString", []string{"database/sql"}, true
+	case pqtbl.DataTypeBool:
+		if optional {
+			return "bool", nil, true
+		}
+		return "sql.NullBool", []string{"database/sql"}, true
+	case pqtbl.DataTypeSmallInteger:
+		return "int16", nil, true
+	case pqtbl.DataTypeInteger:
+		return "int32", nil, true
+	case pqtbl.DataTypeBigInteger:
+		if optional {
+			return "int64", nil, true
+		}
+		return "sql.NullInt64", []string{"database/sql"}, true
+	case pqtbl.DataTypeSerial:
+		if optional {
+			return "uint32", nil, true
+		}
+	case pqtbl.DataTypeBigSerial:
+		if optional {
+			return "uint64", nil, true
+		}
+	case pqtbl.DataTypeTimestamp, pqtbl.DataTypeTimestampTZ:
+		if optional {
+			return "time.Time", []string{"time"}, true
+		}
+		return "*time.Time", []string{"time"}, true
+	}
+
+	if strings.HasPrefix(c.Type, pqtbl.DataTypeVarchar) {
+		return "string", nil, true
+	}
+
+	return "", nil, false
+}
+
+// NtypesMapper maps nullable columns onto github.com/piotrkowalczuk/ntypes
+// types.
+type NtypesMapper struct{}
+
+// GoType implements TypeMapper.
+func (NtypesMapper) GoType(c pqtbl.Column) (string, []string, bool) {
+	const pkg = "github.com/piotrkowalczuk/ntypes"
+	optional := c.NotNull || c.PrimaryKey
+
+	switch c.Type {
+	case pqtbl.DataTypeText:
+		if optional {
+			return "string", nil, true
+		}
+		return "*ntypes.String", []string{pkg}, true
+	case pqtbl.DataTypeBool:
+		if optional {
+			return "bool", nil, true
+		}
+		return "*ntypes.Bool", []string{pkg}, true
+	case pqtbl.DataTypeSmallInteger:
+		return "int16", nil, true
+	case pqtbl.DataTypeInteger:
+		if optional {
+			return "int32", nil, true
+		}
+		return "*ntypes.Int32", []string{pkg}, true
+	case pqtbl.DataTypeBigInteger:
+		if optional {
+			return "int64", nil, true
+		}
+		return "*ntypes.Int64", []string{pkg}, true
+	case pqtbl.DataTypeSerial:
+		if optional {
+			return "uint32", nil, true
+		}
+	case pqtbl.DataTypeBigSerial:
+		if optional {
+			return "uint64", nil, true
+		}
+	case pqtbl.DataTypeTimestamp, pqtbl.DataTypeTimestampTZ:
+		if optional {
+			return "time.Time", []string{"time"}, true
+		}
+		return "*time.Time", []string{"time"}, true
+	}
+
+	if strings.HasPrefix(c.Type, pqtbl.DataTypeVarchar) {
+		return "string", nil, true
+	}
+
+	return "", nil, false
+}
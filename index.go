@@ -0,0 +1,108 @@
+package pqtbl
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrMissingIndexName    = errors.New("pqtbl: missing index name")
+	ErrMissingIndexTable   = errors.New("pqtbl: missing index table")
+	ErrMissingIndexColumns = errors.New("pqtbl: missing index columns")
+)
+
+// Index describes a CREATE INDEX statement.
+type Index struct {
+	Name, Table, Schema string
+	Columns             []string
+	// Method is the index access method, e.g. "btree", "hash", "gin", "gist".
+	// When empty, Postgres' default (btree) applies.
+	Method string
+	// Where turns the index into a partial index.
+	Where        string
+	Unique       bool
+	Concurrently bool
+}
+
+// CreateQuery renders the CREATE INDEX statement for i.
+func (i *Index) CreateQuery() (string, error) {
+	if i.Name == "" {
+		return "", ErrMissingIndexName
+	}
+	if i.Table == "" {
+		return "", ErrMissingIndexTable
+	}
+	if len(i.Columns) == 0 {
+		return "", ErrMissingIndexColumns
+	}
+
+	buf := bytes.NewBufferString("CREATE ")
+	if i.Unique {
+		buf.WriteString("UNIQUE ")
+	}
+	buf.WriteString("INDEX ")
+	if i.Concurrently {
+		buf.WriteString("CONCURRENTLY ")
+	}
+	buf.WriteString(i.Name)
+	buf.WriteString(" ON ")
+	buf.WriteString(qualifiedName(i.Schema, i.Table))
+	if i.Method != "" {
+		buf.WriteString(" USING ")
+		buf.WriteString(i.Method)
+	}
+	buf.WriteString(" (")
+	buf.WriteString(strings.Join(i.Columns, ", "))
+	buf.WriteRune(')')
+	if i.Where != "" {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(i.Where)
+	}
+	buf.WriteString(";")
+
+	return buf.String(), nil
+}
+
+// Statements renders the full DDL script for t: the CREATE TABLE statement,
+// followed by its indexes, followed by the functions and triggers needed to
+// back any Column.DefaultOn entries, followed by explicitly declared
+// triggers.
+func (t *Table) Statements() ([]string, error) {
+	create, err := t.CreateQuery()
+	if err != nil {
+		return nil, err
+	}
+	statements := []string{create}
+
+	for _, idx := range t.Indexes {
+		if idx.Schema == "" {
+			idx.Schema = t.Schema
+		}
+		if idx.Table == "" {
+			idx.Table = t.Name
+		}
+
+		q, err := idx.CreateQuery()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, q)
+	}
+
+	functions, triggers, err := t.defaultOnTriggers()
+	if err != nil {
+		return nil, err
+	}
+	statements = append(statements, functions...)
+
+	for _, tr := range append(triggers, t.Triggers...) {
+		q, err := tr.CreateQuery()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, q)
+	}
+
+	return statements, nil
+}
@@ -0,0 +1,65 @@
+package pqtbl_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+func TestIndex_CreateQuery(t *testing.T) {
+	idx := pqtbl.Index{
+		Name:         "user_email_idx",
+		Table:        "user",
+		Schema:       "public",
+		Columns:      []string{"email"},
+		Unique:       true,
+		Method:       "btree",
+		Where:        "deleted_at IS NULL",
+		Concurrently: true,
+	}
+
+	expected := `CREATE UNIQUE INDEX CONCURRENTLY user_email_idx ON public.user USING btree (email) WHERE deleted_at IS NULL;`
+
+	q, err := idx.CreateQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if q != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, q)
+	}
+}
+
+func TestTable_Statements(t *testing.T) {
+	tbl := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+			{Name: "email", Type: pqtbl.DataTypeText, NotNull: true},
+			{Name: "updated_at", Type: pqtbl.DataTypeTimestampTZ, DefaultOn: map[pqtbl.Event]string{
+				pqtbl.EventUpdate: pqtbl.FunctionNow,
+			}},
+		},
+		Indexes: []pqtbl.Index{
+			{Name: "user_email_idx", Columns: []string{"email"}, Unique: true},
+		},
+	}
+
+	statements, err := tbl.Statements()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if len(statements) != 4 {
+		t.Fatalf("expected 4 statements, got %d: %#v", len(statements), statements)
+	}
+
+	expectedIndex := `CREATE UNIQUE INDEX user_email_idx ON user (email);`
+	if statements[1] != expectedIndex {
+		t.Errorf("wrong index statement, expected %q but got %q", expectedIndex, statements[1])
+	}
+
+	expectedTrigger := `CREATE TRIGGER user_updated_at_update_trg BEFORE UPDATE ON user FOR EACH ROW EXECUTE PROCEDURE user_updated_at_update();`
+	if statements[3] != expectedTrigger {
+		t.Errorf("wrong trigger statement, expected %q but got %q", expectedTrigger, statements[3])
+	}
+}
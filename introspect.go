@@ -0,0 +1,306 @@
+package pqtbl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Introspector reconstructs Table definitions from a live database. It is
+// an interface so that, besides the built-in Postgres implementation,
+// adapters for other engines (MySQL, SQLite, ...) can be plugged in.
+type Introspector interface {
+	Tables(ctx context.Context, db *sql.DB, schema string) ([]Table, error)
+}
+
+// Introspect builds a Table for every table in schema, using the Postgres
+// information_schema/pg_catalog views.
+func Introspect(ctx context.Context, db *sql.DB, schema string) ([]Table, error) {
+	return PostgresIntrospector{}.Tables(ctx, db, schema)
+}
+
+// PostgresIntrospector implements Introspector on top of
+// information_schema.columns, information_schema.table_constraints,
+// information_schema.key_column_usage, information_schema.constraint_column_usage
+// and pg_indexes.
+type PostgresIntrospector struct{}
+
+const introspectColumnsQuery = `
+SELECT table_name, column_name, data_type, udt_name, is_nullable, column_default
+FROM information_schema.columns
+WHERE table_schema = $1
+ORDER BY table_name, ordinal_position
+`
+
+const introspectConstraintsQuery = `
+SELECT
+	tc.table_name,
+	tc.constraint_name,
+	tc.constraint_type,
+	kcu.column_name,
+	ccu.table_schema AS foreign_table_schema,
+	ccu.table_name AS foreign_table_name,
+	ccu.column_name AS foreign_column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+	ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+LEFT JOIN information_schema.constraint_column_usage ccu
+	ON ccu.constraint_schema = tc.constraint_schema AND ccu.constraint_name = tc.constraint_name
+WHERE tc.constraint_schema = $1
+ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position
+`
+
+// introspectIndexesQuery excludes indexes backing a PRIMARY KEY or UNIQUE
+// constraint: those are already reconstructed as Constraint values by
+// introspectConstraintsQuery, and listing them again as Index would
+// duplicate the same database object.
+const introspectIndexesQuery = `
+SELECT ix.tablename, ix.indexname, ix.indexdef
+FROM pg_indexes ix
+JOIN pg_class ic ON ic.relname = ix.indexname
+JOIN pg_namespace ns ON ns.oid = ic.relnamespace AND ns.nspname = ix.schemaname
+LEFT JOIN pg_constraint con ON con.conindid = ic.oid
+WHERE ix.schemaname = $1
+AND con.oid IS NULL
+ORDER BY ix.tablename, ix.indexname
+`
+
+// Tables implements Introspector.
+func (PostgresIntrospector) Tables(ctx context.Context, db *sql.DB, schema string) ([]Table, error) {
+	tables := make(map[string]*Table)
+	var order []string
+
+	tableOf := func(name string) *Table {
+		t, ok := tables[name]
+		if !ok {
+			t = &Table{Name: name, Schema: schema}
+			tables[name] = t
+			order = append(order, name)
+		}
+		return t
+	}
+
+	columnRows, err := db.QueryContext(ctx, introspectColumnsQuery, schema)
+	if err != nil {
+		return nil, fmt.Errorf("pqtbl: introspecting columns: %w", err)
+	}
+	defer columnRows.Close()
+
+	for columnRows.Next() {
+		var (
+			tableName, columnName, dataType, udtName, isNullable string
+			columnDefault                                        sql.NullString
+		)
+		if err := columnRows.Scan(&tableName, &columnName, &dataType, &udtName, &isNullable, &columnDefault); err != nil {
+			return nil, fmt.Errorf("pqtbl: scanning column: %w", err)
+		}
+
+		t := tableOf(tableName)
+		t.Columns = append(t.Columns, Column{
+			Name:    columnName,
+			Type:    pgDataType(dataType, udtName),
+			NotNull: isNullable == "NO",
+			Default: columnDefault.String,
+		})
+	}
+	if err := columnRows.Err(); err != nil {
+		return nil, fmt.Errorf("pqtbl: introspecting columns: %w", err)
+	}
+
+	constraintRows, err := db.QueryContext(ctx, introspectConstraintsQuery, schema)
+	if err != nil {
+		return nil, fmt.Errorf("pqtbl: introspecting constraints: %w", err)
+	}
+	defer constraintRows.Close()
+
+	constraints := make(map[string]map[string]*Constraint)
+	for constraintRows.Next() {
+		var (
+			tableName, constraintName, constraintType, columnName string
+			foreignSchema, foreignTable, foreignColumn            sql.NullString
+		)
+		if err := constraintRows.Scan(&tableName, &constraintName, &constraintType, &columnName, &foreignSchema, &foreignTable, &foreignColumn); err != nil {
+			return nil, fmt.Errorf("pqtbl: scanning constraint: %w", err)
+		}
+
+		byName, ok := constraints[tableName]
+		if !ok {
+			byName = make(map[string]*Constraint)
+			constraints[tableName] = byName
+		}
+
+		c, ok := byName[constraintName]
+		if !ok {
+			c = &Constraint{Name: constraintName}
+			byName[constraintName] = c
+		}
+		c.Columns = append(c.Columns, columnName)
+
+		switch constraintType {
+		case "PRIMARY KEY":
+			c.PrimaryKey = true
+		case "UNIQUE":
+			c.Unique = true
+		case "FOREIGN KEY":
+			c.ReferenceSchema = foreignSchema.String
+			c.ReferenceTable = foreignTable.String
+			c.ReferenceColumns = append(c.ReferenceColumns, foreignColumn.String)
+		}
+	}
+	if err := constraintRows.Err(); err != nil {
+		return nil, fmt.Errorf("pqtbl: introspecting constraints: %w", err)
+	}
+
+	for tableName, byName := range constraints {
+		t := tableOf(tableName)
+		for _, c := range byName {
+			t.Constraints = append(t.Constraints, *c)
+		}
+	}
+
+	indexRows, err := db.QueryContext(ctx, introspectIndexesQuery, schema)
+	if err != nil {
+		return nil, fmt.Errorf("pqtbl: introspecting indexes: %w", err)
+	}
+	defer indexRows.Close()
+
+	for indexRows.Next() {
+		var tableName, indexName, indexDef string
+		if err := indexRows.Scan(&tableName, &indexName, &indexDef); err != nil {
+			return nil, fmt.Errorf("pqtbl: scanning index: %w", err)
+		}
+
+		columns, method, where := parseIndexDef(indexDef)
+
+		t := tableOf(tableName)
+		t.Indexes = append(t.Indexes, Index{
+			Name:    indexName,
+			Table:   tableName,
+			Schema:  schema,
+			Columns: columns,
+			Method:  method,
+			Where:   where,
+			Unique:  strings.Contains(indexDef, "UNIQUE"),
+		})
+	}
+	if err := indexRows.Err(); err != nil {
+		return nil, fmt.Errorf("pqtbl: introspecting indexes: %w", err)
+	}
+
+	result := make([]Table, 0, len(order))
+	for _, name := range order {
+		result = append(result, *tables[name])
+	}
+
+	return result, nil
+}
+
+// parseIndexDef extracts the indexed columns, access method and partial
+// index predicate from a pg_indexes.indexdef value, e.g.
+// "CREATE INDEX users_email_idx ON public.users USING btree (email) WHERE (deleted_at IS NULL)".
+func parseIndexDef(def string) (columns []string, method, where string) {
+	const usingSep = " USING "
+	usingIdx := strings.Index(def, usingSep)
+	if usingIdx == -1 {
+		return nil, "", ""
+	}
+	rest := def[usingIdx+len(usingSep):]
+
+	parenStart := strings.Index(rest, "(")
+	if parenStart == -1 {
+		return nil, "", ""
+	}
+	method = strings.TrimSpace(rest[:parenStart])
+
+	parenEnd := matchingParen(rest, parenStart)
+	if parenEnd == -1 {
+		return nil, method, ""
+	}
+	columns = splitIndexColumns(rest[parenStart+1 : parenEnd])
+
+	if remainder := strings.TrimSpace(rest[parenEnd+1:]); strings.HasPrefix(remainder, "WHERE ") {
+		where = strings.TrimSpace(strings.TrimPrefix(remainder, "WHERE "))
+		where = strings.TrimSuffix(strings.TrimPrefix(where, "("), ")")
+	}
+
+	return columns, method, where
+}
+
+// matchingParen returns the index, within s, of the ")" matching the "("
+// at position open, or -1 if s is malformed.
+func matchingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitIndexColumns splits the column list of an indexdef's USING (...)
+// clause on top-level commas, so that expression columns such as
+// "coalesce(name, email)" are not split on their internal comma.
+func splitIndexColumns(s string) []string {
+	var columns []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				columns = append(columns, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	columns = append(columns, strings.TrimSpace(s[start:]))
+	return columns
+}
+
+// pgDataType maps a Postgres information_schema data_type/udt_name pair onto
+// one of the pqtbl.DataType* constants, falling back to the udt_name, as is,
+// for types this package has no constant for.
+func pgDataType(dataType, udtName string) string {
+	switch dataType {
+	case "smallint":
+		return DataTypeSmallInteger
+	case "integer":
+		return DataTypeInteger
+	case "bigint":
+		return DataTypeBigInteger
+	case "boolean":
+		return DataTypeBool
+	case "text":
+		return DataTypeText
+	case "numeric":
+		return DataTypeDecimal
+	case "money":
+		return DataTypeMoney
+	case "timestamp without time zone":
+		return DataTypeTimestamp
+	case "timestamp with time zone":
+		return DataTypeTimestampTZ
+	case "character varying":
+		return DataTypeVarchar
+	}
+
+	switch udtName {
+	case "serial", "int4":
+		return DataTypeSerial
+	case "bigserial", "int8":
+		return DataTypeBigSerial
+	}
+
+	return udtName
+}
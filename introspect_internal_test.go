@@ -0,0 +1,75 @@
+package pqtbl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPgDataType(t *testing.T) {
+	success := []struct {
+		dataType, udtName, expected string
+	}{
+		{dataType: "integer", udtName: "int4", expected: DataTypeInteger},
+		{dataType: "bigint", udtName: "int8", expected: DataTypeBigInteger},
+		{dataType: "text", udtName: "text", expected: DataTypeText},
+		{dataType: "character varying", udtName: "varchar", expected: DataTypeVarchar},
+		{dataType: "timestamp with time zone", udtName: "timestamptz", expected: DataTypeTimestampTZ},
+		{dataType: "USER-DEFINED", udtName: "serial", expected: DataTypeSerial},
+		{dataType: "USER-DEFINED", udtName: "hstore", expected: "hstore"},
+	}
+
+	for _, data := range success {
+		got := pgDataType(data.dataType, data.udtName)
+		if got != data.expected {
+			t.Errorf("pgDataType(%q, %q): expected %q but got %q", data.dataType, data.udtName, data.expected, got)
+		}
+	}
+}
+
+func TestParseIndexDef(t *testing.T) {
+	cases := []struct {
+		def           string
+		columns       []string
+		method, where string
+	}{
+		{
+			def:     `CREATE INDEX users_email_idx ON public.users USING btree (email)`,
+			columns: []string{"email"},
+			method:  "btree",
+		},
+		{
+			def:     `CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)`,
+			columns: []string{"id"},
+			method:  "btree",
+		},
+		{
+			def:     `CREATE INDEX users_name_email_idx ON public.users USING btree (name, email)`,
+			columns: []string{"name", "email"},
+			method:  "btree",
+		},
+		{
+			def:     `CREATE INDEX users_active_idx ON public.users USING btree (email) WHERE (deleted_at IS NULL)`,
+			columns: []string{"email"},
+			method:  "btree",
+			where:   "deleted_at IS NULL",
+		},
+		{
+			def:     `CREATE INDEX users_lower_email_idx ON public.users USING btree (lower(email))`,
+			columns: []string{"lower(email)"},
+			method:  "btree",
+		},
+	}
+
+	for _, c := range cases {
+		columns, method, where := parseIndexDef(c.def)
+		if !reflect.DeepEqual(columns, c.columns) {
+			t.Errorf("parseIndexDef(%q): expected columns %v but got %v", c.def, c.columns, columns)
+		}
+		if method != c.method {
+			t.Errorf("parseIndexDef(%q): expected method %q but got %q", c.def, c.method, method)
+		}
+		if where != c.where {
+			t.Errorf("parseIndexDef(%q): expected where %q but got %q", c.def, c.where, where)
+		}
+	}
+}
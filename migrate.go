@@ -0,0 +1,300 @@
+package pqtbl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrTableNameMismatch is returned by Migrate when the two tables it is
+	// asked to diff do not share a name.
+	ErrTableNameMismatch = errors.New("pqtbl: migrate requires two tables with the same name")
+	// ErrDestructiveChange is returned by Migrate when a diff would drop a
+	// column or constraint and the AllowDrop option was not given.
+	ErrDestructiveChange = errors.New("pqtbl: destructive change requires the AllowDrop option")
+)
+
+// MigrateOption configures the behaviour of Migrate and MigrateSchema.
+type MigrateOption func(*migrateOptions)
+
+type migrateOptions struct {
+	allowDrop bool
+}
+
+// AllowDrop permits Migrate to emit statements that drop columns or
+// constraints present in the source table but absent from the target.
+// Without it, Migrate refuses such diffs with ErrDestructiveChange.
+func AllowDrop() MigrateOption {
+	return func(o *migrateOptions) {
+		o.allowDrop = true
+	}
+}
+
+// Migrate compares from and to, both describing the same table at different
+// points in time, and returns the ALTER TABLE statements required to bring
+// from in line with to. The result is deterministic: columns and constraints
+// are visited, and dropped, in name order. Columns implied by Relationships
+// (see Table.AddRelationship) are included in the diff the same way their
+// constraints are, so an added OneToOne/OneToMany relationship gets its
+// foreign key column added before the constraint that references it.
+func Migrate(from, to *Table, opts ...MigrateOption) ([]string, error) {
+	if from.Name != to.Name {
+		return nil, ErrTableNameMismatch
+	}
+
+	options := &migrateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	schema, name := to.Schema, to.Name
+
+	fromCols, toCols := from.columns(), to.columns()
+	toCols = append([]Column{}, toCols...)
+	sort.Slice(toCols, func(i, j int) bool { return toCols[i].Name < toCols[j].Name })
+
+	fromColumns := make(map[string]Column, len(fromCols))
+	for _, c := range fromCols {
+		fromColumns[c.Name] = c
+	}
+	toColumns := make(map[string]Column, len(toCols))
+	for _, c := range toCols {
+		toColumns[c.Name] = c
+	}
+
+	var statements []string
+	consumed := make(map[string]bool, len(fromCols))
+
+	for _, c := range toCols {
+		existing, ok := fromColumns[c.Name]
+		if !ok && c.RenameFrom != "" {
+			if old, renamed := fromColumns[c.RenameFrom]; renamed {
+				statements = append(statements, renameColumnQuery(schema, name, c.RenameFrom, c.Name))
+				consumed[c.RenameFrom] = true
+				existing, ok = old, true
+			}
+		}
+		if !ok {
+			statements = append(statements, addColumnQuery(schema, name, c))
+			continue
+		}
+
+		statements = append(statements, alterColumnQueries(schema, name, existing, c)...)
+	}
+
+	var dropped []string
+	for _, c := range fromCols {
+		if consumed[c.Name] {
+			continue
+		}
+		if _, ok := toColumns[c.Name]; ok {
+			continue
+		}
+
+		if !options.allowDrop {
+			return nil, fmt.Errorf("%w: column %q", ErrDestructiveChange, c.Name)
+		}
+		dropped = append(dropped, c.Name)
+	}
+	sort.Strings(dropped)
+	for _, name := range dropped {
+		statements = append(statements, dropColumnQuery(schema, to.Name, name))
+	}
+
+	constraintStatements, err := migrateConstraints(from, to, options)
+	if err != nil {
+		return nil, err
+	}
+	statements = append(statements, constraintStatements...)
+
+	return statements, nil
+}
+
+func migrateConstraints(from, to *Table, options *migrateOptions) ([]string, error) {
+	fromConstraints, err := from.namedConstraints()
+	if err != nil {
+		return nil, err
+	}
+	toConstraints, err := to.namedConstraints()
+	if err != nil {
+		return nil, err
+	}
+
+	fromByName := make(map[string]namedConstraint, len(fromConstraints))
+	for _, c := range fromConstraints {
+		fromByName[c.Name] = c
+	}
+	toByName := make(map[string]namedConstraint, len(toConstraints))
+	for _, c := range toConstraints {
+		toByName[c.Name] = c
+	}
+
+	var added, dropped []string
+	for _, c := range toConstraints {
+		if _, ok := fromByName[c.Name]; !ok {
+			added = append(added, fmt.Sprintf("ALTER TABLE %s ADD %s;", qualifiedName(to.Schema, to.Name), c.Query))
+		}
+	}
+	for _, c := range fromConstraints {
+		if _, ok := toByName[c.Name]; ok {
+			continue
+		}
+		if !options.allowDrop {
+			return nil, fmt.Errorf("%w: constraint %q", ErrDestructiveChange, c.Name)
+		}
+		dropped = append(dropped, fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT "%s";`, qualifiedName(to.Schema, to.Name), c.Name))
+	}
+
+	sort.Strings(added)
+	sort.Strings(dropped)
+
+	return append(added, dropped...), nil
+}
+
+// MigrateSchema compares two full schemas - typically the previous and the
+// current version of a set of tables managed by the application - and
+// returns the statements required to bring from in line with to. Tables
+// present in to but absent from from are created in full; tables present in
+// from but absent from to are dropped, which also requires the AllowDrop
+// option.
+func MigrateSchema(from, to []Table, opts ...MigrateOption) ([]string, error) {
+	options := &migrateOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	fromByName := make(map[string]*Table, len(from))
+	for i := range from {
+		fromByName[from[i].Name] = &from[i]
+	}
+	toByName := make(map[string]*Table, len(to))
+	for i := range to {
+		toByName[to[i].Name] = &to[i]
+	}
+
+	var statements []string
+
+	names := make([]string, 0, len(to))
+	for i := range to {
+		names = append(names, to[i].Name)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		t := toByName[n]
+		existing, ok := fromByName[n]
+		if !ok {
+			q, err := t.CreateQuery()
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, q)
+			continue
+		}
+
+		diff, err := Migrate(existing, t, opts...)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, diff...)
+	}
+
+	var dropNames []string
+	for n := range fromByName {
+		if _, ok := toByName[n]; ok {
+			continue
+		}
+		dropNames = append(dropNames, n)
+	}
+	sort.Strings(dropNames)
+
+	for _, n := range dropNames {
+		if !options.allowDrop {
+			return nil, fmt.Errorf("%w: table %q", ErrDestructiveChange, n)
+		}
+		q, err := fromByName[n].DropQuery(false, false)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, q)
+	}
+
+	return statements, nil
+}
+
+// DropQuery renders a DROP TABLE statement for t. ifExists adds IF EXISTS,
+// cascade adds CASCADE.
+func (t *Table) DropQuery(ifExists, cascade bool) (string, error) {
+	if t.Name == "" {
+		return "", ErrMissingTableName
+	}
+
+	q := "DROP TABLE "
+	if ifExists {
+		q += "IF EXISTS "
+	}
+	q += qualifiedName(t.Schema, t.Name)
+	if cascade {
+		q += " CASCADE"
+	}
+	q += ";"
+
+	return q, nil
+}
+
+func qualifiedName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+func addColumnQuery(schema, table string, c Column) string {
+	q := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", qualifiedName(schema, table), c.Name, c.Type)
+	if c.Collate != "" {
+		q += " " + c.Collate
+	}
+	if c.Default != "" {
+		q += " DEFAULT " + c.Default
+	}
+	if c.NotNull {
+		q += " NOT NULL"
+	}
+	return q + ";"
+}
+
+func dropColumnQuery(schema, table, column string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", qualifiedName(schema, table), column)
+}
+
+func renameColumnQuery(schema, table, from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", qualifiedName(schema, table), from, to)
+}
+
+// alterColumnQueries returns the statements required to bring column from in
+// line with column to, assuming both share a name.
+func alterColumnQueries(schema, table string, from, to Column) []string {
+	var statements []string
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", qualifiedName(schema, table), to.Name)
+
+	if from.Type != to.Type {
+		statements = append(statements, fmt.Sprintf("%s TYPE %s;", prefix, to.Type))
+	}
+	if from.NotNull != to.NotNull {
+		if to.NotNull {
+			statements = append(statements, fmt.Sprintf("%s SET NOT NULL;", prefix))
+		} else {
+			statements = append(statements, fmt.Sprintf("%s DROP NOT NULL;", prefix))
+		}
+	}
+	if from.Default != to.Default {
+		if to.Default == "" {
+			statements = append(statements, fmt.Sprintf("%s DROP DEFAULT;", prefix))
+		} else {
+			statements = append(statements, fmt.Sprintf("%s SET DEFAULT %s;", prefix, to.Default))
+		}
+	}
+
+	return statements
+}
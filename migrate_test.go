@@ -0,0 +1,140 @@
+package pqtbl_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+func TestMigrate(t *testing.T) {
+	from := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+			{Name: "username", Type: pqtbl.DataTypeText},
+			{Name: "legacy", Type: pqtbl.DataTypeText},
+		},
+	}
+	to := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+			{Name: "username", Type: pqtbl.DataTypeText, NotNull: true},
+			{Name: "email", Type: pqtbl.DataTypeText, RenameFrom: "legacy"},
+		},
+	}
+
+	statements, err := pqtbl.Migrate(&from, &to)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{
+		`ALTER TABLE user RENAME COLUMN legacy TO email;`,
+		`ALTER TABLE user ALTER COLUMN username SET NOT NULL;`,
+	}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("wrong statements, expected:\n%#v\nbut got:\n%#v", expected, statements)
+	}
+}
+
+func TestMigrate_destructiveRequiresAllowDrop(t *testing.T) {
+	from := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+			{Name: "nickname", Type: pqtbl.DataTypeText},
+		},
+	}
+	to := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+		},
+	}
+
+	if _, err := pqtbl.Migrate(&from, &to); !errors.Is(err, pqtbl.ErrDestructiveChange) {
+		t.Fatalf("expected ErrDestructiveChange, got %v", err)
+	}
+
+	statements, err := pqtbl.Migrate(&from, &to, pqtbl.AllowDrop())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{`ALTER TABLE user DROP COLUMN nickname;`}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("wrong statements, expected:\n%#v\nbut got:\n%#v", expected, statements)
+	}
+}
+
+func TestMigrate_addColumnKeepsCollate(t *testing.T) {
+	from := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+		},
+	}
+	to := pqtbl.Table{
+		Name: "user",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+			{Name: "username", Type: pqtbl.DataTypeText, Collate: "UTF-8", NotNull: true, Default: "''"},
+		},
+	}
+
+	statements, err := pqtbl.Migrate(&from, &to)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{`ALTER TABLE user ADD COLUMN username TEXT UTF-8 DEFAULT '' NOT NULL;`}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("wrong statements, expected:\n%#v\nbut got:\n%#v", expected, statements)
+	}
+}
+
+func TestMigrate_addsRelationshipColumnBeforeItsConstraint(t *testing.T) {
+	category := pqtbl.NewTable("category").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+
+	from := pqtbl.Table{
+		Name: "product",
+		Columns: []pqtbl.Column{
+			{Name: "id", Type: pqtbl.DataTypeSerial, PrimaryKey: true},
+		},
+	}
+
+	to := pqtbl.NewTable("product").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+	to.AddRelationship(pqtbl.OneToMany(category, to, pqtbl.WithColumnName("category_id")))
+
+	statements, err := pqtbl.Migrate(&from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := []string{
+		`ALTER TABLE product ADD COLUMN category_id INTEGER;`,
+		`ALTER TABLE product ADD CONSTRAINT "public.product_category_id_fkey" FOREIGN KEY (category_id) REFERENCES category (id);`,
+	}
+	if !reflect.DeepEqual(statements, expected) {
+		t.Errorf("wrong statements, expected:\n%#v\nbut got:\n%#v", expected, statements)
+	}
+}
+
+func TestTable_DropQuery(t *testing.T) {
+	tbl := pqtbl.Table{Name: "user", Schema: "public"}
+
+	q, err := tbl.DropQuery(true, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	expected := `DROP TABLE IF EXISTS public.user CASCADE;`
+	if q != expected {
+		t.Errorf("wrong query, expected %q but got %q", expected, q)
+	}
+}
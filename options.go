@@ -0,0 +1,149 @@
+package pqtbl
+
+// TableOption configures a Table constructed with NewTable.
+type TableOption func(*Table)
+
+// WithSchema sets the schema the table belongs to.
+func WithSchema(schema string) TableOption {
+	return func(t *Table) {
+		t.Schema = schema
+	}
+}
+
+// WithTemporary marks the table as TEMPORARY.
+func WithTemporary() TableOption {
+	return func(t *Table) {
+		t.Temporary = true
+	}
+}
+
+// WithTableIfNotExists adds IF NOT EXISTS to the CREATE TABLE statement.
+func WithTableIfNotExists() TableOption {
+	return func(t *Table) {
+		t.IfNotExists = true
+	}
+}
+
+// WithTableCollate sets Table.Collate. CreateQuery does not render it; only
+// column collations (see WithCollate) appear in the generated DDL.
+func WithTableCollate(collate string) TableOption {
+	return func(t *Table) {
+		t.Collate = collate
+	}
+}
+
+// WithTableSpace sets Table.TableSpace. CreateQuery does not render a
+// TABLESPACE clause for it.
+func WithTableSpace(tableSpace string) TableOption {
+	return func(t *Table) {
+		t.TableSpace = tableSpace
+	}
+}
+
+// NewTable allocates a Table with the given name and applies opts to it.
+func NewTable(name string, opts ...TableOption) *Table {
+	t := &Table{Name: name}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// AddColumn appends c to the table columns and returns the table so calls can be chained.
+func (t *Table) AddColumn(c *Column) *Table {
+	t.Columns = append(t.Columns, *c)
+	return t
+}
+
+// AddConstraint appends c to the table constraints and returns the table so calls can be chained.
+func (t *Table) AddConstraint(c Constraint) *Table {
+	t.Constraints = append(t.Constraints, c)
+	return t
+}
+
+// ColumnOption configures a Column constructed with NewColumn.
+type ColumnOption func(*Column)
+
+// WithNotNull marks the column as NOT NULL.
+func WithNotNull() ColumnOption {
+	return func(c *Column) {
+		c.NotNull = true
+	}
+}
+
+// WithPrimaryKey marks the column as the table's primary key.
+func WithPrimaryKey() ColumnOption {
+	return func(c *Column) {
+		c.PrimaryKey = true
+	}
+}
+
+// WithUnique marks the column as unique.
+func WithUnique() ColumnOption {
+	return func(c *Column) {
+		c.Unique = true
+	}
+}
+
+// WithDefault sets the column's DEFAULT expression.
+func WithDefault(expr string) ColumnOption {
+	return func(c *Column) {
+		c.Default = expr
+	}
+}
+
+// WithCheck attaches a CHECK expression to the column.
+func WithCheck(expr string) ColumnOption {
+	return func(c *Column) {
+		c.Check = expr
+	}
+}
+
+// WithCollate sets the column's collation.
+func WithCollate(collate string) ColumnOption {
+	return func(c *Column) {
+		c.Collate = collate
+	}
+}
+
+// WithReference turns the column into a foreign key referencing column of
+// table. Trailing opts are applied after the reference is set, so that
+// foreign key actions (WithOnDelete, WithOnUpdate) can be attached in the
+// same expression.
+func WithReference(table *Table, column *Column, opts ...ColumnOption) ColumnOption {
+	return func(c *Column) {
+		c.ReferenceSchema = table.Schema
+		c.ReferenceTable = table.Name
+		c.ReferenceColumn = column.Name
+		for _, opt := range opts {
+			opt(c)
+		}
+	}
+}
+
+// WithOnDelete sets the FOREIGN KEY ON DELETE action, e.g. "CASCADE" or
+// "SET NULL". Only meaningful alongside WithReference.
+func WithOnDelete(action string) ColumnOption {
+	return func(c *Column) {
+		c.OnDelete = action
+	}
+}
+
+// WithOnUpdate sets the FOREIGN KEY ON UPDATE action, e.g. "CASCADE" or
+// "SET NULL". Only meaningful alongside WithReference.
+func WithOnUpdate(action string) ColumnOption {
+	return func(c *Column) {
+		c.OnUpdate = action
+	}
+}
+
+// NewColumn allocates a Column with the given name and type and applies opts to it.
+func NewColumn(name, typ string, opts ...ColumnOption) *Column {
+	c := &Column{Name: name, Type: typ}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
@@ -0,0 +1,84 @@
+package pqtbl_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+func TestNewTable(t *testing.T) {
+	news := pqtbl.NewTable("news", pqtbl.WithSchema("public"), pqtbl.WithTableIfNotExists()).
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey())).
+		AddColumn(pqtbl.NewColumn("title", pqtbl.DataTypeText, pqtbl.WithNotNull())).
+		AddColumn(pqtbl.NewColumn("slug", pqtbl.DataTypeText, pqtbl.WithUnique()))
+
+	expected := `CREATE TABLE IF NOT EXISTS public.news (
+	id SERIAL,
+	title TEXT NOT NULL,
+	slug TEXT,
+
+	CONSTRAINT "public.news_pkey" PRIMARY KEY (id),
+	CONSTRAINT "public.news_slug_key" UNIQUE (slug)
+);`
+
+	q, err := news.CreateQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if q != expected {
+		t.Errorf("wrong query, expected:\n%s\nbut got:\n%s", expected, q)
+	}
+}
+
+func TestNewColumn_WithReference(t *testing.T) {
+	related := pqtbl.NewTable("related_table", pqtbl.WithSchema("public")).
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+	relatedID := related.Columns[0]
+
+	c := pqtbl.NewColumn("rel_id", pqtbl.DataTypeInteger, pqtbl.WithReference(related, &relatedID))
+
+	if c.ReferenceSchema != "public" {
+		t.Errorf("wrong reference schema, expected %q but got %q", "public", c.ReferenceSchema)
+	}
+	if c.ReferenceTable != "related_table" {
+		t.Errorf("wrong reference table, expected %q but got %q", "related_table", c.ReferenceTable)
+	}
+	if c.ReferenceColumn != "id" {
+		t.Errorf("wrong reference column, expected %q but got %q", "id", c.ReferenceColumn)
+	}
+}
+
+func TestNewColumn_WithReference_actions(t *testing.T) {
+	related := pqtbl.NewTable("related_table").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+	relatedID := related.Columns[0]
+
+	c := pqtbl.NewColumn("rel_id", pqtbl.DataTypeInteger, pqtbl.WithReference(
+		related, &relatedID,
+		pqtbl.WithOnDelete("CASCADE"),
+		pqtbl.WithOnUpdate("SET NULL"),
+	))
+
+	if c.OnDelete != "CASCADE" {
+		t.Errorf("wrong on delete action, expected %q but got %q", "CASCADE", c.OnDelete)
+	}
+	if c.OnUpdate != "SET NULL" {
+		t.Errorf("wrong on update action, expected %q but got %q", "SET NULL", c.OnUpdate)
+	}
+
+	tbl := pqtbl.NewTable("dependent").AddColumn(c)
+
+	expected := `CREATE TABLE dependent (
+	rel_id INTEGER,
+
+	CONSTRAINT "public.dependent_rel_id_fkey" FOREIGN KEY (rel_id) REFERENCES related_table (id) ON DELETE CASCADE ON UPDATE SET NULL
+);`
+
+	q, err := tbl.CreateQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if q != expected {
+		t.Errorf("wrong query, expected:\n%s\nbut got:\n%s", expected, q)
+	}
+}
@@ -36,6 +36,28 @@ type Table struct {
 	IfNotExists, Temporary            bool
 	Columns                           []Column
 	Constraints                       []Constraint
+	Indexes                           []Index
+	Triggers                          []Trigger
+	Relationships                     []Relationship
+}
+
+// columns returns t.Columns plus the foreign key columns implied by t's
+// owned relationships, so that CreateQuery and constraints stay the single
+// place that materializes a table's DDL.
+func (t *Table) columns() []Column {
+	if len(t.Relationships) == 0 {
+		return t.Columns
+	}
+
+	columns := append([]Column{}, t.Columns...)
+	for _, r := range t.OwnedRelationships() {
+		if r.Type == RelationshipManyToMany {
+			continue
+		}
+		columns = append(columns, r.foreignKeyColumn())
+	}
+
+	return columns
 }
 
 func (t *Table) CreateQuery() (string, error) {
@@ -66,7 +88,8 @@ func (t *Table) CreateQuery() (string, error) {
 		buf.WriteString(t.Name)
 	}
 	buf.WriteString(" (\n")
-	for i, c := range t.Columns {
+	columns := t.columns()
+	for i, c := range columns {
 		buf.WriteRune('	')
 		buf.WriteString(c.Name)
 		buf.WriteRune(' ')
@@ -82,7 +105,7 @@ func (t *Table) CreateQuery() (string, error) {
 		if c.NotNull {
 			buf.WriteString(" NOT NULL")
 		}
-		if i < len(t.Columns)-1 || len(constraints) > 0 {
+		if i < len(columns)-1 || len(constraints) > 0 {
 			buf.WriteRune(',')
 		}
 		buf.WriteRune('\n')
@@ -107,49 +130,96 @@ func (t *Table) CreateQuery() (string, error) {
 }
 
 func (t *Table) constraints() ([]string, error) {
-	constraints := make([]string, 0, len(t.Columns)+len(t.Constraints))
+	named, err := t.namedConstraints()
+	if err != nil {
+		return nil, err
+	}
 
-	for _, c := range t.Columns {
+	constraints := make([]string, 0, len(named))
+	for _, n := range named {
+		constraints = append(constraints, n.Query)
+	}
+
+	return constraints, nil
+}
+
+// namedConstraint pairs a constraint's fully qualified name with its DDL query,
+// so that callers (e.g. Migrate) can reason about constraints by identity.
+type namedConstraint struct {
+	Name, Query string
+}
+
+func (t *Table) namedConstraints() ([]namedConstraint, error) {
+	columns := t.columns()
+	constraints := make([]namedConstraint, 0, len(columns)+len(t.Constraints))
+
+	for _, c := range columns {
 		if c.Unique && !c.PrimaryKey {
-			constraints = append(constraints, uniqueConstraintQuery(t.Schema, t.Name, c.Name))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.Unique(t.Schema, t.Name, c.Name).String(),
+				Query: uniqueConstraintQuery(t.Schema, t.Name, c.Name),
+			})
 		}
 		if c.PrimaryKey && !c.Unique {
-			constraints = append(constraints, primaryKeyConstraintQuery(t.Schema, t.Name, c.Name))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.PrimaryKey(t.Schema, t.Name).String(),
+				Query: primaryKeyConstraintQuery(t.Schema, t.Name, c.Name),
+			})
 		}
 		if c.isReference() {
 			if !c.isValidReference() {
 				return nil, fmt.Errorf("pqtbl: invalid foreign key column schema: '%s', table: '%s', column: '%s'", c.ReferenceSchema, c.ReferenceTable, c.ReferenceColumn)
 			}
 
-			constraints = append(constraints, foreignKeyConstraintQuery(t.Schema, t.Name, []string{c.Name}, c.ReferenceSchema, c.ReferenceTable, []string{c.ReferenceColumn}))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.ForeignKey(t.Schema, t.Name, c.Name).String(),
+				Query: foreignKeyConstraintQuery(t.Schema, t.Name, []string{c.Name}, c.ReferenceSchema, c.ReferenceTable, []string{c.ReferenceColumn}, c.OnDelete, c.OnUpdate),
+			})
 		}
 
 		if c.Check != "" {
-			constraints = append(constraints, checkConstraintQuery(t.Schema, t.Name, c.Check, c.Name))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.Check(t.Schema, t.Name, c.Name).String(),
+				Query: checkConstraintQuery(t.Schema, t.Name, c.Check, c.Name),
+			})
 		}
 	}
 
 	for _, c := range t.Constraints {
 		if c.Unique && !c.PrimaryKey {
-			constraints = append(constraints, uniqueConstraintQuery(t.Schema, t.Name, c.Columns...))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.Unique(t.Schema, t.Name, c.Columns...).String(),
+				Query: uniqueConstraintQuery(t.Schema, t.Name, c.Columns...),
+			})
 		}
 		if c.PrimaryKey && !c.Unique {
-			constraints = append(constraints, primaryKeyConstraintQuery(t.Schema, t.Name, c.Columns...))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.PrimaryKey(t.Schema, t.Name).String(),
+				Query: primaryKeyConstraintQuery(t.Schema, t.Name, c.Columns...),
+			})
 		}
 		if c.isReference() {
 			if !c.isValidReference() {
 				return nil, fmt.Errorf("pqtbl: invalid foreign key column schema: '%s', table: '%s', columns: '%#v'", c.ReferenceSchema, c.ReferenceTable, c.ReferenceColumns)
 			}
 
-			constraints = append(constraints, foreignKeyConstraintQuery(t.Schema, t.Name, c.Columns, c.ReferenceSchema, c.ReferenceTable, c.ReferenceColumns))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.ForeignKey(t.Schema, t.Name, c.Columns...).String(),
+				Query: foreignKeyConstraintQuery(t.Schema, t.Name, c.Columns, c.ReferenceSchema, c.ReferenceTable, c.ReferenceColumns, c.OnDelete, c.OnUpdate),
+			})
 		}
 
 		if c.Check != "" {
-			constraints = append(constraints, checkConstraintQuery(t.Schema, t.Name, c.Check, c.Columns...))
+			constraints = append(constraints, namedConstraint{
+				Name:  pqcnstr.Check(t.Schema, t.Name, c.Columns...).String(),
+				Query: checkConstraintQuery(t.Schema, t.Name, c.Check, c.Columns...),
+			})
 		}
 	}
 
-	sort.Strings(constraints)
+	sort.Slice(constraints, func(i, j int) bool {
+		return constraints[i].Name < constraints[j].Name
+	})
 	return constraints, nil
 }
 
@@ -157,6 +227,19 @@ type Column struct {
 	Name, Type, Collate, Default, Check              string
 	NotNull, Unique, PrimaryKey                      bool
 	ReferenceTable, ReferenceColumn, ReferenceSchema string
+	// OnDelete and OnUpdate are the FOREIGN KEY action clauses (e.g. "CASCADE",
+	// "SET NULL") applied when the column is a reference; see WithReference.
+	OnDelete, OnUpdate string
+	// RenameFrom hints that this column replaces a column of that name on the
+	// previous version of the table, so that Migrate emits a RENAME COLUMN
+	// instead of a DROP COLUMN/ADD COLUMN pair.
+	RenameFrom string
+	// DefaultOn maps an Event to an expression that should be assigned to the
+	// column whenever that event fires. Table.Statements synthesizes a
+	// BEFORE trigger (and its backing function) per entry, e.g. DefaultOn:
+	// map[Event]string{EventUpdate: pqtbl.FunctionNow} keeps updated_at
+	// current without a static DEFAULT.
+	DefaultOn map[Event]string
 }
 
 func (c *Column) isReference() bool {
@@ -350,7 +433,7 @@ func primaryKeyConstraintQuery(schema, table string, columns ...string) string {
 	return fmt.Sprintf(`CONSTRAINT "%s" PRIMARY KEY (%s)`, pqcnstr.PrimaryKey(schema, table).String(), strings.Join(columns, ", "))
 }
 
-func foreignKeyConstraintQuery(schema, table string, columns []string, referenceSchema, referenceTable string, referenceColumns []string) string {
+func foreignKeyConstraintQuery(schema, table string, columns []string, referenceSchema, referenceTable string, referenceColumns []string, onDelete, onUpdate string) string {
 	var reference string
 	if referenceSchema == "" {
 		reference = referenceTable
@@ -358,7 +441,15 @@ func foreignKeyConstraintQuery(schema, table string, columns []string, reference
 		reference = referenceSchema + "." + referenceTable
 	}
 
-	return fmt.Sprintf(`CONSTRAINT "%s" FOREIGN KEY (%s) REFERENCES %s (%s)`, pqcnstr.ForeignKey(schema, table, columns...).String(), strings.Join(columns, ", "), reference, strings.Join(referenceColumns, ", "))
+	query := fmt.Sprintf(`CONSTRAINT "%s" FOREIGN KEY (%s) REFERENCES %s (%s)`, pqcnstr.ForeignKey(schema, table, columns...).String(), strings.Join(columns, ", "), reference, strings.Join(referenceColumns, ", "))
+	if onDelete != "" {
+		query += " ON DELETE " + onDelete
+	}
+	if onUpdate != "" {
+		query += " ON UPDATE " + onUpdate
+	}
+
+	return query
 }
 
 func checkConstraintQuery(schema, table, check string, columns ...string) string {
@@ -0,0 +1,194 @@
+package pqtbl
+
+// RelationshipType identifies the cardinality of a Relationship.
+type RelationshipType int
+
+const (
+	RelationshipOneToOne RelationshipType = iota
+	RelationshipOneToMany
+	RelationshipManyToMany
+)
+
+// Relationship describes an association between two tables. Owner is the
+// side that, once materialized by CreateQuery, carries the foreign key
+// column; Inversed is the side it references. ManyToMany relationships own
+// neither side directly and instead get an implicit JoinTable.
+type Relationship struct {
+	Type            RelationshipType
+	Owner, Inversed *Table
+	// ColumnName is the foreign key column materialized on the owner side.
+	// Defaults to Inversed.Name + "_id".
+	ColumnName string
+	// OwnerName and InversedName are the navigation field names downstream
+	// code generators should use on the owner and inversed side respectively.
+	OwnerName, InversedName string
+	Bidirectional           bool
+	Self                    bool
+	// JoinTable is populated for ManyToMany relationships with the implicit
+	// join table carrying a foreign key to each side.
+	JoinTable *Table
+}
+
+// RelationshipOption configures a Relationship constructed with OneToOne,
+// OneToMany or ManyToMany.
+type RelationshipOption func(*Relationship)
+
+// WithColumnName overrides the foreign key column name materialized on the
+// owner side. It does not affect ManyToMany join tables, whose columns are
+// always named after each side's table ("<table>_id").
+func WithColumnName(name string) RelationshipOption {
+	return func(r *Relationship) {
+		r.ColumnName = name
+	}
+}
+
+// WithOwnerName sets the navigation field name used on the owner side.
+func WithOwnerName(name string) RelationshipOption {
+	return func(r *Relationship) {
+		r.OwnerName = name
+	}
+}
+
+// WithInversedName sets the navigation field name used on the inversed side.
+func WithInversedName(name string) RelationshipOption {
+	return func(r *Relationship) {
+		r.InversedName = name
+	}
+}
+
+// WithBidirectional marks the relationship as navigable from both sides.
+func WithBidirectional() RelationshipOption {
+	return func(r *Relationship) {
+		r.Bidirectional = true
+	}
+}
+
+// SelfReference marks a relationship where Owner and Inversed are the same
+// table, e.g. a category tree referencing its own parent.
+func SelfReference() RelationshipOption {
+	return func(r *Relationship) {
+		r.Self = true
+	}
+}
+
+func newRelationship(typ RelationshipType, owner, inversed *Table, opts ...RelationshipOption) Relationship {
+	r := Relationship{Type: typ, Owner: owner, Inversed: inversed}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	if r.ColumnName == "" {
+		r.ColumnName = inversed.Name + "_id"
+	}
+	if r.OwnerName == "" {
+		r.OwnerName = inversed.Name
+	}
+	if r.InversedName == "" {
+		r.InversedName = owner.Name
+	}
+
+	return r
+}
+
+// OneToOne declares a relationship where owner carries a unique foreign key
+// referencing inversed.
+func OneToOne(owner, inversed *Table, opts ...RelationshipOption) Relationship {
+	return newRelationship(RelationshipOneToOne, owner, inversed, opts...)
+}
+
+// OneToMany declares a relationship where many carries a foreign key
+// referencing the single side, one.
+func OneToMany(one, many *Table, opts ...RelationshipOption) Relationship {
+	return newRelationship(RelationshipOneToMany, many, one, opts...)
+}
+
+// ManyToMany declares a relationship between a and b backed by an implicit
+// join table carrying a foreign key to each side's primary key.
+func ManyToMany(a, b *Table, opts ...RelationshipOption) Relationship {
+	r := newRelationship(RelationshipManyToMany, a, b, opts...)
+	r.JoinTable = joinTable(a, b)
+
+	return r
+}
+
+func joinTable(a, b *Table) *Table {
+	aColumn := a.Name + "_id"
+	bColumn := b.Name + "_id"
+
+	join := NewTable(a.Name+"_"+b.Name, WithSchema(a.Schema))
+	join.
+		AddColumn(NewColumn(aColumn, DataTypeInteger, WithNotNull(), WithReference(a, a.primaryKeyColumn()))).
+		AddColumn(NewColumn(bColumn, DataTypeInteger, WithNotNull(), WithReference(b, b.primaryKeyColumn())))
+	join.AddConstraint(Constraint{PrimaryKey: true, Columns: []string{aColumn, bColumn}})
+
+	return join
+}
+
+// primaryKeyColumn returns the table's primary key column, or a synthetic
+// "id" reference when none is declared.
+func (t *Table) primaryKeyColumn() *Column {
+	for i := range t.Columns {
+		if t.Columns[i].PrimaryKey {
+			return &t.Columns[i]
+		}
+	}
+
+	return &Column{Name: "id"}
+}
+
+// foreignKeyColumn builds the owner-side foreign key column implied by r.
+func (r *Relationship) foreignKeyColumn() Column {
+	c := Column{
+		Name:            r.ColumnName,
+		Type:            DataTypeInteger,
+		ReferenceSchema: r.Inversed.Schema,
+		ReferenceTable:  r.Inversed.Name,
+		ReferenceColumn: r.Inversed.primaryKeyColumn().Name,
+		Unique:          r.Type == RelationshipOneToOne,
+	}
+
+	return c
+}
+
+// AddRelationship registers r on t and on the other table it connects, so
+// that both sides' OwnedRelationships/InversedRelationships see it.
+func (t *Table) AddRelationship(r Relationship) *Table {
+	t.Relationships = append(t.Relationships, r)
+
+	var other *Table
+	switch t {
+	case r.Owner:
+		other = r.Inversed
+	case r.Inversed:
+		other = r.Owner
+	}
+	if other != nil && other != t {
+		other.Relationships = append(other.Relationships, r)
+	}
+
+	return t
+}
+
+// OwnedRelationships returns the relationships in which t carries the
+// foreign key.
+func (t *Table) OwnedRelationships() []Relationship {
+	var owned []Relationship
+	for _, r := range t.Relationships {
+		if r.Owner == t {
+			owned = append(owned, r)
+		}
+	}
+
+	return owned
+}
+
+// InversedRelationships returns the relationships in which t is referenced.
+func (t *Table) InversedRelationships() []Relationship {
+	var inversed []Relationship
+	for _, r := range t.Relationships {
+		if r.Inversed == t {
+			inversed = append(inversed, r)
+		}
+	}
+
+	return inversed
+}
@@ -0,0 +1,70 @@
+package pqtbl_test
+
+import (
+	"testing"
+
+	"github.com/piotrkowalczuk/pqtbl"
+)
+
+func TestTable_AddRelationship_oneToMany(t *testing.T) {
+	parent := pqtbl.NewTable("category").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+	child := pqtbl.NewTable("product").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey())).
+		AddColumn(pqtbl.NewColumn("name", pqtbl.DataTypeText, pqtbl.WithNotNull()))
+
+	child.AddRelationship(pqtbl.OneToMany(parent, child, pqtbl.WithColumnName("category_id")))
+
+	if owned := child.OwnedRelationships(); len(owned) != 1 {
+		t.Fatalf("expected product to own 1 relationship, got %d", len(owned))
+	}
+	if inversed := parent.InversedRelationships(); len(inversed) != 1 {
+		t.Fatalf("expected category to be inversed in 1 relationship, got %d", len(inversed))
+	}
+
+	expected := `CREATE TABLE product (
+	id SERIAL,
+	name TEXT NOT NULL,
+	category_id INTEGER,
+
+	CONSTRAINT "public.product_category_id_fkey" FOREIGN KEY (category_id) REFERENCES category (id),
+	CONSTRAINT "public.product_pkey" PRIMARY KEY (id)
+);`
+
+	q, err := child.CreateQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if q != expected {
+		t.Errorf("wrong query, expected:\n%s\nbut got:\n%s", expected, q)
+	}
+}
+
+func TestManyToMany_joinTable(t *testing.T) {
+	user := pqtbl.NewTable("user").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+	group := pqtbl.NewTable("group").
+		AddColumn(pqtbl.NewColumn("id", pqtbl.DataTypeSerial, pqtbl.WithPrimaryKey()))
+
+	rel := pqtbl.ManyToMany(user, group)
+	if rel.JoinTable == nil {
+		t.Fatal("expected a join table to be created")
+	}
+
+	expected := `CREATE TABLE user_group (
+	user_id INTEGER NOT NULL,
+	group_id INTEGER NOT NULL,
+
+	CONSTRAINT "public.user_group_group_id_fkey" FOREIGN KEY (group_id) REFERENCES group (id),
+	CONSTRAINT "public.user_group_pkey" PRIMARY KEY (user_id, group_id),
+	CONSTRAINT "public.user_group_user_id_fkey" FOREIGN KEY (user_id) REFERENCES user (id)
+);`
+
+	q, err := rel.JoinTable.CreateQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if q != expected {
+		t.Errorf("wrong query, expected:\n%s\nbut got:\n%s", expected, q)
+	}
+}
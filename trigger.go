@@ -0,0 +1,108 @@
+package pqtbl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrMissingTriggerName   = errors.New("pqtbl: missing trigger name")
+	ErrMissingTriggerTable  = errors.New("pqtbl: missing trigger table")
+	ErrMissingTriggerEvents = errors.New("pqtbl: missing trigger events")
+)
+
+// Event is a table event a Trigger can fire on.
+type Event string
+
+const (
+	EventInsert Event = "INSERT"
+	EventUpdate Event = "UPDATE"
+	EventDelete Event = "DELETE"
+)
+
+// Trigger describes a CREATE TRIGGER statement that calls Function, which is
+// expected to already exist (or be part of the same Table.Statements script).
+type Trigger struct {
+	Name, Schema, Table, Function string
+	// Timing is either "BEFORE" or "AFTER".
+	Timing string
+	Events []Event
+	// ForEachRow selects FOR EACH ROW when true, FOR EACH STATEMENT otherwise.
+	ForEachRow bool
+}
+
+// CreateQuery renders the CREATE TRIGGER statement for tr.
+func (tr *Trigger) CreateQuery() (string, error) {
+	if tr.Name == "" {
+		return "", ErrMissingTriggerName
+	}
+	if tr.Table == "" {
+		return "", ErrMissingTriggerTable
+	}
+	if len(tr.Events) == 0 {
+		return "", ErrMissingTriggerEvents
+	}
+
+	events := make([]string, 0, len(tr.Events))
+	for _, e := range tr.Events {
+		events = append(events, string(e))
+	}
+
+	buf := bytes.NewBufferString("CREATE TRIGGER ")
+	buf.WriteString(tr.Name)
+	buf.WriteRune(' ')
+	buf.WriteString(tr.Timing)
+	buf.WriteRune(' ')
+	buf.WriteString(strings.Join(events, " OR "))
+	buf.WriteString(" ON ")
+	buf.WriteString(qualifiedName(tr.Schema, tr.Table))
+	buf.WriteString(" FOR EACH ")
+	if tr.ForEachRow {
+		buf.WriteString("ROW")
+	} else {
+		buf.WriteString("STATEMENT")
+	}
+	buf.WriteString(" EXECUTE PROCEDURE ")
+	buf.WriteString(tr.Function)
+	buf.WriteString("();")
+
+	return buf.String(), nil
+}
+
+// defaultOnTriggers synthesizes, for every column that declares DefaultOn,
+// the plpgsql function and BEFORE trigger needed to keep it up to date.
+func (t *Table) defaultOnTriggers() (functions []string, triggers []Trigger, err error) {
+	for _, c := range t.Columns {
+		if len(c.DefaultOn) == 0 {
+			continue
+		}
+
+		events := make([]Event, 0, len(c.DefaultOn))
+		for e := range c.DefaultOn {
+			events = append(events, e)
+		}
+		sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+
+		for _, e := range events {
+			fn := fmt.Sprintf("%s_%s_%s", t.Name, c.Name, strings.ToLower(string(e)))
+			functions = append(functions, fmt.Sprintf(
+				"CREATE OR REPLACE FUNCTION %s() RETURNS TRIGGER AS $$\nBEGIN\n\tNEW.%s = %s;\n\tRETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;",
+				fn, c.Name, c.DefaultOn[e],
+			))
+			triggers = append(triggers, Trigger{
+				Name:       fn + "_trg",
+				Schema:     t.Schema,
+				Table:      t.Name,
+				Timing:     "BEFORE",
+				Events:     []Event{e},
+				Function:   fn,
+				ForEachRow: true,
+			})
+		}
+	}
+
+	return functions, triggers, nil
+}